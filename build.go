@@ -0,0 +1,104 @@
+package pack
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/paths"
+)
+
+// BuildOptions configures a Build invocation.
+type BuildOptions struct {
+	Builder build.Builder
+
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	Network    string
+
+	Platform build.Platform
+
+	// PreviousImage is an image ref to rebase onto, preserving its
+	// labels/metadata, instead of building from scratch.
+	PreviousImage string
+
+	// Layout, when set, points analyze/restore/build/export at an on-disk
+	// OCI image layout directory instead of a daemon or registry.
+	Layout *build.LayoutConfig
+
+	PlatformAPI *api.Version
+
+	// AppPath is the app source directory to upload into the lifecycle's
+	// app volume before the build runs.
+	AppPath string
+
+	// Image is the ref the produced app image should be tagged as.
+	Image string
+}
+
+// Build runs the full lifecycle (detect, analyze, restore, build, export)
+// against opts.Builder, producing an app image.
+func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+	if err := c.validatePlatform(ctx, opts.Builder.Name(), opts.Platform); err != nil {
+		return err
+	}
+
+	layout, err := resolveLayout(opts.Layout)
+	if err != nil {
+		return fmt.Errorf("resolving layout directory: %w", err)
+	}
+
+	lifecycleExec := build.NewLifecycleExecution(c.logger, c.docker, c.backend, runtime.GOOS, opts.PlatformAPI, build.LifecycleOptions{
+		Builder:       opts.Builder,
+		HTTPProxy:     opts.HTTPProxy,
+		HTTPSProxy:    opts.HTTPSProxy,
+		NoProxy:       opts.NoProxy,
+		Network:       opts.Network,
+		Platform:      opts.Platform,
+		PreviousImage: opts.PreviousImage,
+		Layout:        layout,
+		AppPath:       opts.AppPath,
+		Image:         opts.Image,
+	})
+
+	if err := lifecycleExec.Build(ctx); err != nil {
+		return fmt.Errorf("running build: %w", err)
+	}
+	return nil
+}
+
+// resolveLayout canonicalizes a LayoutConfig's image refs to `oci:<path>`
+// form, whether the caller passed a bare directory path or an oci: ref
+// already; refs with a URI scheme are left as they are. Only LayoutRepoDir
+// reaches the lifecycle phases; InputImage and PreviousInputImage are not
+// passed to any phase.
+func resolveLayout(layout *build.LayoutConfig) (*build.LayoutConfig, error) {
+	if layout == nil {
+		return nil, nil
+	}
+
+	resolved := *layout
+	if ref, err := normalizeLayoutRef(layout.InputImage); err != nil {
+		return nil, err
+	} else {
+		resolved.InputImage = ref
+	}
+	if ref, err := normalizeLayoutRef(layout.PreviousInputImage); err != nil {
+		return nil, err
+	} else {
+		resolved.PreviousInputImage = ref
+	}
+	return &resolved, nil
+}
+
+func normalizeLayoutRef(ref string) (string, error) {
+	if ref == "" || strings.HasPrefix(ref, "oci:") || paths.IsURI(ref) {
+		return ref, nil
+	}
+	return paths.LayoutDirToRef(ref)
+}
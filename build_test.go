@@ -0,0 +1,38 @@
+package pack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/pack/internal/build"
+)
+
+func TestResolveLayoutNil(t *testing.T) {
+	resolved, err := resolveLayout(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved != nil {
+		t.Fatalf("expected nil LayoutConfig to stay nil, got %+v", resolved)
+	}
+}
+
+func TestResolveLayoutCanonicalizesDirPaths(t *testing.T) {
+	resolved, err := resolveLayout(&build.LayoutConfig{InputImage: "testdata/layout"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(resolved.InputImage, "oci:") {
+		t.Fatalf("expected InputImage to be canonicalized to an oci: ref, got %s", resolved.InputImage)
+	}
+}
+
+func TestResolveLayoutLeavesExistingRefsAlone(t *testing.T) {
+	resolved, err := resolveLayout(&build.LayoutConfig{InputImage: "oci:/already/resolved"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.InputImage != "oci:/already/resolved" {
+		t.Fatalf("expected an already-canonical ref to pass through unchanged, got %s", resolved.InputImage)
+	}
+}
@@ -0,0 +1,22 @@
+package pack
+
+import (
+	"github.com/docker/docker/client"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/logging"
+)
+
+// Client is the entry point for pack's build/detect/create-builder
+// operations.
+type Client struct {
+	logger  *logging.Logger
+	docker  client.CommonAPIClient
+	backend build.PhaseBackend
+}
+
+// NewClient returns a Client that runs lifecycle phases against backend
+// (typically a build.DockerPhaseBackend).
+func NewClient(logger *logging.Logger, docker client.CommonAPIClient, backend build.PhaseBackend) *Client {
+	return &Client{logger: logger, docker: docker, backend: backend}
+}
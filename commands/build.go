@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack"
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/logging"
+)
+
+type BuildFlags struct {
+	AppPath       string
+	Builder       string
+	Network       string
+	Platform      string
+	PreviousImage string
+}
+
+// Build constructs a `pack build` command, which runs the full lifecycle
+// (detect, analyze, restore, build, export) against an app directory using
+// the --builder image, producing an app image.
+func Build(logger *logging.Logger, client *pack.Client) *cobra.Command {
+	var flags BuildFlags
+	ctx := createCancellableContext()
+	cmd := &cobra.Command{
+		Use:   "build <image>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Build an app image from source code",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			appPath := flags.AppPath
+			if appPath == "" {
+				wd, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("determining current working directory: %w", err)
+				}
+				appPath = wd
+			}
+
+			platform, err := build.ParsePlatform(flags.Platform)
+			if err != nil {
+				return err
+			}
+
+			return client.Build(ctx, pack.BuildOptions{
+				Builder:       builderRef(flags.Builder),
+				Network:       flags.Network,
+				PreviousImage: flags.PreviousImage,
+				AppPath:       appPath,
+				Platform:      platform,
+				Image:         args[0],
+			})
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", "", "Builder image to use (required)")
+	cmd.MarkFlagRequired("builder")
+	cmd.Flags().StringVar(&flags.Network, "network", "", "Connect detect and build containers to network")
+	cmd.Flags().StringVar(&flags.Platform, "platform", "", "Target platform to build for, in os/arch[/variant] form (defaults to the container runtime's default platform)")
+	cmd.Flags().StringVar(&flags.PreviousImage, "previous-image", "", "Rebase onto the given previously built image instead of analyzing from scratch (requires a builder with platform API 0.7+)")
+	AddHelpFlag(cmd, "build")
+	return cmd
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestBuildHasPreviousImageFlag(t *testing.T) {
+	cmd := Build(nil, nil)
+
+	flag := cmd.Flags().Lookup("previous-image")
+	if flag == nil {
+		t.Fatal("expected a --previous-image flag")
+	}
+	if flag.DefValue != "" {
+		t.Fatalf("expected --previous-image to default to empty, got %q", flag.DefValue)
+	}
+}
+
+func TestBuildRequiresBuilderFlag(t *testing.T) {
+	cmd := Build(nil, nil)
+
+	flag := cmd.Flags().Lookup("builder")
+	if flag == nil {
+		t.Fatal("expected a --builder flag")
+	}
+	if required := flag.Annotations[cobra.BashCompOneRequiredFlag]; len(required) == 0 {
+		t.Fatal("expected --builder to be marked required")
+	}
+}
+
+func TestBuildHasPlatformFlag(t *testing.T) {
+	cmd := Build(nil, nil)
+
+	flag := cmd.Flags().Lookup("platform")
+	if flag == nil {
+		t.Fatal("expected a --platform flag")
+	}
+	if flag.DefValue != "" {
+		t.Fatalf("expected --platform to default to empty, got %q", flag.DefValue)
+	}
+}
+
+func TestBuildRequiresExactlyOneImageArg(t *testing.T) {
+	cmd := Build(nil, nil)
+
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Fatal("expected an error when no image arg is given")
+	}
+	if err := cmd.Args(cmd, []string{"my-image"}); err != nil {
+		t.Fatalf("expected a single image arg to be accepted, got error: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"my-image", "extra"}); err == nil {
+		t.Fatal("expected an error when more than one image arg is given")
+	}
+}
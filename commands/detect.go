@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack"
+	"github.com/buildpacks/pack/logging"
+)
+
+type DetectFlags struct {
+	AppPath string
+	Builder string
+	Output  string
+}
+
+// Detect constructs a `pack detect` command, which runs only the analyze and
+// detect lifecycle phases against an app directory and prints the resolved
+// buildpack group without producing an image. With --output toml or json the
+// detect plan is printed too; the default output lists only the group. It is meant for CI checks and
+// editor tooling that want to answer "will this project build?" quickly.
+func Detect(logger *logging.Logger, client *pack.Client) *cobra.Command {
+	var flags DetectFlags
+	ctx := createCancellableContext()
+	cmd := &cobra.Command{
+		Use:   "detect",
+		Args:  cobra.NoArgs,
+		Short: "Run group detection against a source tree without building an image",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Output != "" && flags.Output != "toml" && flags.Output != "json" {
+				return fmt.Errorf("unsupported --output %s, must be one of: toml, json", flags.Output)
+			}
+
+			appPath := flags.AppPath
+			if appPath == "" {
+				wd, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("determining current working directory: %w", err)
+				}
+				appPath = wd
+			}
+
+			result, err := client.Detect(ctx, pack.DetectOptions{
+				Builder: builderRef(flags.Builder),
+				AppPath: appPath,
+			})
+			if err != nil {
+				return err
+			}
+
+			return printDetectResult(cmd, result, flags.Output)
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", "", "Builder image to detect against (required)")
+	cmd.MarkFlagRequired("builder")
+	cmd.Flags().StringVar(&flags.Output, "output", "", "Output format for the resolved group: toml, json")
+	AddHelpFlag(cmd, "detect")
+	return cmd
+}
+
+// builderRef is the minimal build.Builder that naming a builder image by
+// its ref alone (as the --builder flag does) needs to satisfy.
+type builderRef string
+
+func (b builderRef) Name() string { return string(b) }
+
+func printDetectResult(cmd *cobra.Command, result pack.DetectResult, output string) error {
+	switch output {
+	case "json":
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case "toml":
+		return toml.NewEncoder(cmd.OutOrStdout()).Encode(result)
+	default:
+		for _, bp := range result.Group.Group {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s@%s\n", bp.ID, bp.Version)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack"
+	"github.com/buildpacks/pack/internal/build"
+)
+
+func TestBuilderRefName(t *testing.T) {
+	var b build.Builder = builderRef("index.docker.io/some/builder")
+	if b.Name() != "index.docker.io/some/builder" {
+		t.Fatalf("expected Name() to return the ref unchanged, got %s", b.Name())
+	}
+}
+
+func TestPrintDetectResultDefaultFormat(t *testing.T) {
+	result := pack.DetectResult{
+		Group: build.Group{Group: []build.GroupBuildpack{
+			{ID: "some/buildpack", Version: "1.2.3"},
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	if err := printDetectResult(cmd, result, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "some/buildpack@1.2.3\n"
+	if buf.String() != want {
+		t.Fatalf("expected output %q, got %q", want, buf.String())
+	}
+}
+
+func TestPrintDetectResultJSON(t *testing.T) {
+	result := pack.DetectResult{
+		Group: build.Group{Group: []build.GroupBuildpack{{ID: "some/buildpack", Version: "1.2.3"}}},
+	}
+
+	buf := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	if err := printDetectResult(cmd, result, "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("expected non-empty JSON output")
+	}
+}
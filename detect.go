@@ -0,0 +1,46 @@
+package pack
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/internal/build"
+)
+
+// DetectOptions configures a Detect invocation.
+type DetectOptions struct {
+	Builder     build.Builder
+	PlatformAPI *api.Version
+
+	// AppPath is the app source directory to upload into the lifecycle's
+	// app volume before detect runs.
+	AppPath string
+}
+
+// DetectResult is the resolved buildpack group (and, on platform APIs that
+// produce one, detect plan) that `pack detect` found.
+type DetectResult struct {
+	Group build.Group
+	Plan  build.Plan
+}
+
+// Detect runs only the analyze and detect lifecycle phases against
+// opts.Builder and returns the resolved group (and detect plan, where
+// supported) without producing an image.
+func (c *Client) Detect(ctx context.Context, opts DetectOptions) (DetectResult, error) {
+	lifecycleExec := build.NewLifecycleExecution(c.logger, c.docker, c.backend, runtime.GOOS, opts.PlatformAPI, build.LifecycleOptions{
+		Builder:    opts.Builder,
+		DetectOnly: true,
+		AppPath:    opts.AppPath,
+	})
+
+	group, plan, err := lifecycleExec.Detect(ctx)
+	if err != nil {
+		return DetectResult{}, fmt.Errorf("running detect: %w", err)
+	}
+
+	return DetectResult{Group: group, Plan: plan}, nil
+}
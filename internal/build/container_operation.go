@@ -0,0 +1,75 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerOperation writes data (app source, a project descriptor, etc)
+// into a tar stream that a PhaseBackend delivers into a phase's container
+// before it starts running, so a phase never has to wait on its backend's
+// own copy-into-running-container semantics.
+type ContainerOperation func(tw *tar.Writer) error
+
+// buildOpsTar runs every ContainerOperation against a single tar stream,
+// returning the raw archive bytes a backend can hand to its runtime's
+// "copy into container" primitive.
+func buildOpsTar(ops []ContainerOperation) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	for _, op := range ops {
+		if err := op(tw); err != nil {
+			return nil, fmt.Errorf("building container operation archive: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("closing container operation archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// AppDirOp returns a ContainerOperation that walks appDir on the host and
+// writes every regular file underneath it into the tar stream rooted at
+// destDir, preserving the directory's relative structure.
+func AppDirOp(appDir, destDir string) ContainerOperation {
+	return func(tw *tar.Writer) error {
+		return filepath.Walk(appDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(appDir, path)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			name := strings.TrimPrefix(filepath.ToSlash(filepath.Join(destDir, rel)), "/")
+			if err := tw.WriteHeader(&tar.Header{
+				Name: name,
+				Size: info.Size(),
+				Mode: int64(info.Mode().Perm()),
+			}); err != nil {
+				return err
+			}
+
+			_, err = io.Copy(tw, f)
+			return err
+		})
+	}
+}
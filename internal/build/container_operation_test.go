@@ -0,0 +1,71 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildOpsTar(t *testing.T) {
+	op := func(tw *tar.Writer) error {
+		content := []byte("hello")
+		if err := tw.WriteHeader(&tar.Header{Name: "app/hello.txt", Size: int64(len(content)), Mode: 0644}); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	archive, err := buildOpsTar([]ContainerOperation{op})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tr := tar.NewReader(bytes.NewReader(archive))
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("expected a tar entry, got error: %v", err)
+	}
+	if hdr.Name != "app/hello.txt" {
+		t.Errorf("expected entry named app/hello.txt, got %s", hdr.Name)
+	}
+}
+
+func TestBuildOpsTarPropagatesOpError(t *testing.T) {
+	failing := func(tw *tar.Writer) error {
+		return errBoom
+	}
+
+	if _, err := buildOpsTar([]ContainerOperation{failing}); err == nil {
+		t.Fatal("expected an error from a failing ContainerOperation to propagate")
+	}
+}
+
+func TestAppDirOpCopiesFilesUnderDestDir(t *testing.T) {
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatalf("writing fixture file: %v", err)
+	}
+
+	archive, err := buildOpsTar([]ContainerOperation{AppDirOp(appDir, "/workspace")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tr := tar.NewReader(bytes.NewReader(archive))
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("expected a tar entry, got error: %v", err)
+	}
+	if hdr.Name != "workspace/main.go" {
+		t.Errorf("expected entry named workspace/main.go, got %s", hdr.Name)
+	}
+}
+
+var errBoom = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
@@ -0,0 +1,171 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// DockerPhaseBackend runs lifecycle phases as short-lived containers against
+// a Docker daemon. It is the default PhaseBackend and reproduces the
+// behavior PhaseConfigProvider has always driven directly.
+type DockerPhaseBackend struct {
+	docker client.CommonAPIClient
+}
+
+func NewDockerPhaseBackend(docker client.CommonAPIClient) *DockerPhaseBackend {
+	return &DockerPhaseBackend{docker: docker}
+}
+
+func (b *DockerPhaseBackend) Run(ctx context.Context, spec PhaseSpec) (io.ReadCloser, error) {
+	ctrConf := &container.Config{
+		Image:  spec.Image,
+		Cmd:    spec.Cmd,
+		Env:    spec.Env,
+		User:   spec.User,
+		Labels: map[string]string{"author": "pack"},
+	}
+	hostConf := &container.HostConfig{
+		Binds:       toBinds(spec.Mounts),
+		NetworkMode: container.NetworkMode(spec.Network),
+		Isolation:   container.Isolation(spec.Isolation),
+	}
+
+	ctr, err := b.docker.ContainerCreate(ctx, ctrConf, hostConf, nil, platformFor(spec), containerName(spec))
+	if err != nil {
+		return nil, fmt.Errorf("creating container for phase %s: %w", spec.Name, err)
+	}
+
+	if len(spec.ContainerOps) > 0 {
+		archive, err := buildOpsTar(spec.ContainerOps)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.docker.CopyToContainer(ctx, ctr.ID, "/", bytes.NewReader(archive), types.CopyToContainerOptions{}); err != nil {
+			return nil, fmt.Errorf("uploading container operations for phase %s: %w", spec.Name, err)
+		}
+	}
+
+	bodyChan, errChan := b.docker.ContainerWait(ctx, ctr.ID, container.WaitConditionNextExit)
+	if err := b.docker.ContainerStart(ctx, ctr.ID, types.ContainerStartOptions{}); err != nil {
+		return nil, fmt.Errorf("starting container for phase %s: %w", spec.Name, err)
+	}
+
+	var statusCode int64
+	select {
+	case err := <-errChan:
+		return nil, fmt.Errorf("waiting for phase %s to exit: %w", spec.Name, err)
+	case body := <-bodyChan:
+		statusCode = body.StatusCode
+	}
+
+	logs, err := b.docker.ContainerLogs(ctx, ctr.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("attaching to logs for phase %s: %w", spec.Name, err)
+	}
+
+	if statusCode != 0 {
+		defer logs.Close()
+		if spec.ErrorWriter != nil {
+			io.Copy(spec.ErrorWriter, logs)
+		}
+		return nil, fmt.Errorf("%s phase failed with status code: %d", spec.Name, statusCode)
+	}
+
+	return logs, nil
+}
+
+func (b *DockerPhaseBackend) Cleanup(ctx context.Context, spec PhaseSpec) error {
+	return b.docker.ContainerRemove(ctx, containerName(spec), types.ContainerRemoveOptions{Force: true})
+}
+
+// CleanupBuild removes the named volumes a build or detect invocation's
+// phases share (see LifecycleExecution.layersVolume/appVolume), once the
+// full phase sequence is done with them. Phase-scoped Cleanup can't do this
+// itself since the same volumes are still needed by later phases.
+func (b *DockerPhaseBackend) CleanupBuild(ctx context.Context, buildID string) error {
+	var err error
+	for _, name := range []string{layersVolumeName(buildID), appVolumeName(buildID)} {
+		if rmErr := b.docker.VolumeRemove(ctx, name, true); rmErr != nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+// ReadFile pulls a single file out of spec's mounts by briefly starting a
+// throwaway container with the same image and mounts and using Docker's
+// copy-from-container API, rather than reaching back into whatever
+// container Run already cleaned up.
+func (b *DockerPhaseBackend) ReadFile(ctx context.Context, spec PhaseSpec, path string) ([]byte, error) {
+	ctr, err := b.docker.ContainerCreate(ctx,
+		&container.Config{Image: spec.Image, Cmd: []string{"true"}},
+		&container.HostConfig{Binds: toBinds(spec.Mounts)},
+		nil, nil, "",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating reader container: %w", err)
+	}
+	defer b.docker.ContainerRemove(ctx, ctr.ID, types.ContainerRemoveOptions{Force: true})
+
+	reader, _, err := b.docker.CopyFromContainer(ctx, ctr.ID, path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	if _, err := tr.Next(); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := io.Copy(buf, tr); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// containerName deterministically names a phase's container after its
+// build and phase name, so Cleanup can address it without having to thread
+// the ID Docker assigned back out of Run.
+func containerName(spec PhaseSpec) string {
+	return fmt.Sprintf("pack-%s-%s", spec.BuildID, spec.Name)
+}
+
+// platformFor returns the `platform` query parameter Docker expects on
+// container create, or nil to let the daemon pick its default (the common
+// case, when no explicit --platform was requested).
+func platformFor(spec PhaseSpec) *specs.Platform {
+	if spec.Platform.isEmpty() {
+		return nil
+	}
+	return &specs.Platform{
+		OS:           spec.Platform.OS,
+		Architecture: spec.Platform.Architecture,
+		Variant:      spec.Platform.Variant,
+		OSVersion:    spec.Platform.OSVersion,
+	}
+}
+
+func toBinds(mounts []PhaseMount) []string {
+	binds := make([]string, 0, len(mounts))
+	for _, m := range mounts {
+		bind := fmt.Sprintf("%s:%s", m.Source, m.Target)
+		if m.ReadOnly {
+			bind += ":ro"
+		}
+		binds = append(binds, bind)
+	}
+	return binds
+}
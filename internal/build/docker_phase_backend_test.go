@@ -0,0 +1,40 @@
+package build
+
+import "testing"
+
+func TestToBinds(t *testing.T) {
+	binds := toBinds([]PhaseMount{
+		{Source: "pack-layers-abc", Target: "/layers"},
+		{Source: "pack-app-abc", Target: "/workspace", ReadOnly: true},
+	})
+
+	want := []string{"pack-layers-abc:/layers", "pack-app-abc:/workspace:ro"}
+	if len(binds) != len(want) {
+		t.Fatalf("expected %v, got %v", want, binds)
+	}
+	for i := range want {
+		if binds[i] != want[i] {
+			t.Errorf("expected bind %d to be %q, got %q", i, want[i], binds[i])
+		}
+	}
+}
+
+func TestPlatformForEmptyIsNil(t *testing.T) {
+	if platformFor(PhaseSpec{}) != nil {
+		t.Fatalf("expected nil platform for an empty PhaseSpec.Platform")
+	}
+}
+
+func TestPlatformForSetValue(t *testing.T) {
+	p := platformFor(PhaseSpec{Platform: Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}})
+	if p == nil || p.OS != "linux" || p.Architecture != "arm64" || p.Variant != "v8" {
+		t.Fatalf("expected platform to carry through OS/Architecture/Variant, got %+v", p)
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	name := containerName(PhaseSpec{BuildID: "abc123", Name: "detect"})
+	if name != "pack-abc123-detect" {
+		t.Fatalf("expected a deterministic pack-<buildID>-<phase> name, got %s", name)
+	}
+}
@@ -0,0 +1,292 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	kubernetesPhaseContainerName = "phase"
+	kubernetesUploadContainer    = "uploads"
+)
+
+// KubernetesPhaseBackend runs each lifecycle phase as a single Pod against a
+// Kubernetes cluster. It is intended for running pack build in-cluster,
+// where a Docker daemon may not be reachable or desirable.
+type KubernetesPhaseBackend struct {
+	client     kubernetes.Interface
+	restConfig *rest.Config
+	namespace  string
+}
+
+func NewKubernetesPhaseBackend(client kubernetes.Interface, restConfig *rest.Config, namespace string) *KubernetesPhaseBackend {
+	return &KubernetesPhaseBackend{client: client, restConfig: restConfig, namespace: namespace}
+}
+
+func (b *KubernetesPhaseBackend) Run(ctx context.Context, spec PhaseSpec) (io.ReadCloser, error) {
+	pod := b.podFor(spec)
+
+	created, err := b.client.CoreV1().Pods(b.namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("creating pod for phase %s: %w", spec.Name, err)
+	}
+
+	if len(spec.ContainerOps) > 0 {
+		archive, err := buildOpsTar(spec.ContainerOps)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.waitForContainerRunning(ctx, created.Name, kubernetesUploadContainer); err != nil {
+			return nil, fmt.Errorf("waiting to upload container operations for phase %s: %w", spec.Name, err)
+		}
+		if err := b.streamToContainer(ctx, created.Name, kubernetesUploadContainer, bytes.NewReader(archive)); err != nil {
+			return nil, fmt.Errorf("uploading container operations for phase %s: %w", spec.Name, err)
+		}
+	}
+
+	if err := b.waitForContainerRunning(ctx, created.Name, kubernetesPhaseContainerName); err != nil {
+		return nil, fmt.Errorf("waiting for phase %s to start: %w", spec.Name, err)
+	}
+
+	exitCode, err := b.waitForContainerExit(ctx, created.Name, kubernetesPhaseContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for phase %s to exit: %w", spec.Name, err)
+	}
+
+	req := b.client.CoreV1().Pods(b.namespace).GetLogs(created.Name, &corev1.PodLogOptions{
+		Container: kubernetesPhaseContainerName,
+	})
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("streaming logs for phase %s: %w", spec.Name, err)
+	}
+
+	if exitCode != 0 {
+		defer stream.Close()
+		if spec.ErrorWriter != nil {
+			io.Copy(spec.ErrorWriter, stream)
+		}
+		return nil, fmt.Errorf("%s phase failed with status code: %d", spec.Name, exitCode)
+	}
+
+	return stream, nil
+}
+
+func (b *KubernetesPhaseBackend) Cleanup(ctx context.Context, spec PhaseSpec) error {
+	policy := metav1.DeletePropagationBackground
+	return b.client.CoreV1().Pods(b.namespace).Delete(ctx, podName(spec), metav1.DeleteOptions{
+		PropagationPolicy: &policy,
+	})
+}
+
+// CleanupBuild is a no-op: unlike the Docker backend's named volumes, each
+// phase's emptyDir volumes live and die with that phase's own Pod, which
+// Cleanup already deletes.
+func (b *KubernetesPhaseBackend) CleanupBuild(ctx context.Context, buildID string) error {
+	return nil
+}
+
+// ReadFile is not yet supported on the Kubernetes backend: a phase's Pod
+// and its emptyDir volumes are gone by the time Cleanup returns, so there's
+// nothing left to read from.
+func (b *KubernetesPhaseBackend) ReadFile(ctx context.Context, spec PhaseSpec, path string) ([]byte, error) {
+	return nil, fmt.Errorf("reading files back out of a phase is not yet supported on the Kubernetes backend")
+}
+
+// podFor materializes a PhaseSpec as a Pod: an initContainer receives any
+// WithContainerOperations uploads (app source, project descriptor, ...) as
+// a tar stream on its stdin and extracts them into the shared volumes
+// before the phase container runs. Volumes/mounts mirror spec.Mounts
+// exactly, so the lifecycle binary finds files at the same paths its
+// CNB_* env vars and flags were told to expect.
+func (b *KubernetesPhaseBackend) podFor(spec PhaseSpec) *corev1.Pod {
+	volumes, mounts := volumesFor(spec)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName(spec),
+			Namespace: b.namespace,
+			Labels:    map[string]string{"author": "pack"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{
+				{
+					Name:         kubernetesUploadContainer,
+					Image:        spec.Image,
+					Command:      []string{"sh", "-c", "tar -xf - -C /"},
+					Stdin:        true,
+					StdinOnce:    true,
+					Env:          toEnvVars(spec.Env),
+					VolumeMounts: mounts,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:         kubernetesPhaseContainerName,
+					Image:        spec.Image,
+					Command:      spec.Cmd,
+					Env:          toEnvVars(spec.Env),
+					VolumeMounts: mounts,
+				},
+			},
+			Volumes: volumes,
+		},
+	}
+}
+
+// volumesFor creates one emptyDir volume per spec.Mounts entry, mounted at
+// the mount's actual Target path, so a PhaseSpec built for Docker binds
+// lands in the same place under Kubernetes.
+func volumesFor(spec PhaseSpec) ([]corev1.Volume, []corev1.VolumeMount) {
+	volumes := make([]corev1.Volume, 0, len(spec.Mounts))
+	mounts := make([]corev1.VolumeMount, 0, len(spec.Mounts))
+
+	for i, m := range spec.Mounts {
+		name := fmt.Sprintf("mount-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name:         name,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: m.Target,
+			ReadOnly:  m.ReadOnly,
+		})
+	}
+
+	return volumes, mounts
+}
+
+// waitForContainerRunning blocks until the named container (an init
+// container or the main phase container) has started, so log/exec
+// operations against it don't race its creation.
+func (b *KubernetesPhaseBackend) waitForContainerRunning(ctx context.Context, podName, containerName string) error {
+	watcher, err := b.client.CoreV1().Pods(b.namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", podName),
+	})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for event := range watcher.ResultChan() {
+		pod, ok := event.Object.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		if containerRunning(pod.Status.InitContainerStatuses, containerName) ||
+			containerRunning(pod.Status.ContainerStatuses, containerName) {
+			return nil
+		}
+		if failed, reason := containerFailed(pod.Status.InitContainerStatuses, containerName); failed {
+			return fmt.Errorf("container %s failed: %s", containerName, reason)
+		}
+	}
+	return ctx.Err()
+}
+
+// waitForContainerExit blocks until the named container has a terminal
+// status and returns its exit code, so Run can report a failing phase as an
+// error instead of the Pod's terminal state going uninspected.
+func (b *KubernetesPhaseBackend) waitForContainerExit(ctx context.Context, podName, containerName string) (int32, error) {
+	watcher, err := b.client.CoreV1().Pods(b.namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", podName),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer watcher.Stop()
+
+	for event := range watcher.ResultChan() {
+		pod, ok := event.Object.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		if code, done := containerExitCode(pod.Status.ContainerStatuses, containerName); done {
+			return code, nil
+		}
+	}
+	return 0, ctx.Err()
+}
+
+func containerExitCode(statuses []corev1.ContainerStatus, name string) (int32, bool) {
+	for _, s := range statuses {
+		if s.Name == name && s.State.Terminated != nil {
+			return s.State.Terminated.ExitCode, true
+		}
+	}
+	return 0, false
+}
+
+func containerRunning(statuses []corev1.ContainerStatus, name string) bool {
+	for _, s := range statuses {
+		if s.Name == name && (s.State.Running != nil || s.State.Terminated != nil) {
+			return true
+		}
+	}
+	return false
+}
+
+func containerFailed(statuses []corev1.ContainerStatus, name string) (bool, string) {
+	for _, s := range statuses {
+		if s.Name == name && s.State.Terminated != nil && s.State.Terminated.ExitCode != 0 {
+			return true, s.State.Terminated.Reason
+		}
+	}
+	return false, ""
+}
+
+// streamToContainer pipes r into containerName's stdin using the same
+// exec/attach mechanism `kubectl cp` relies on.
+func (b *KubernetesPhaseBackend) streamToContainer(ctx context.Context, podName, containerName string, r io.Reader) error {
+	req := b.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(b.namespace).
+		SubResource("attach").
+		VersionedParams(&corev1.PodAttachOptions{
+			Container: containerName,
+			Stdin:     true,
+			Stdout:    false,
+			Stderr:    false,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(b.restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: r})
+}
+
+func podName(spec PhaseSpec) string {
+	return fmt.Sprintf("pack-%s-%s", spec.BuildID, spec.Name)
+}
+
+func toEnvVars(env []string) []corev1.EnvVar {
+	vars := make([]corev1.EnvVar, 0, len(env))
+	for _, kv := range env {
+		name, value, _ := splitEnv(kv)
+		vars = append(vars, corev1.EnvVar{Name: name, Value: value})
+	}
+	return vars
+}
+
+func splitEnv(kv string) (name, value string, ok bool) {
+	for i := 0; i < len(kv); i++ {
+		if kv[i] == '=' {
+			return kv[:i], kv[i+1:], true
+		}
+	}
+	return kv, "", false
+}
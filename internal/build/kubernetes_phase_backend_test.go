@@ -0,0 +1,66 @@
+package build
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestVolumesForMirrorsMountTargets(t *testing.T) {
+	spec := PhaseSpec{
+		Mounts: []PhaseMount{
+			{Source: "pack-layers-abc", Target: "/layers"},
+			{Source: "pack-app-abc", Target: "/workspace", ReadOnly: true},
+		},
+	}
+
+	volumes, mounts := volumesFor(spec)
+
+	if len(volumes) != 2 || len(mounts) != 2 {
+		t.Fatalf("expected 2 volumes and 2 mounts, got %d volumes and %d mounts", len(volumes), len(mounts))
+	}
+	if mounts[0].MountPath != "/layers" || mounts[1].MountPath != "/workspace" {
+		t.Fatalf("expected mount paths to mirror spec.Mounts targets, got %+v", mounts)
+	}
+	if !mounts[1].ReadOnly {
+		t.Errorf("expected second mount to preserve ReadOnly from spec.Mounts")
+	}
+	if volumes[0].Name != mounts[0].Name {
+		t.Errorf("expected volume and mount names to match, got %s vs %s", volumes[0].Name, mounts[0].Name)
+	}
+}
+
+func TestPodNameIsUniquePerBuildAndPhase(t *testing.T) {
+	a := podName(PhaseSpec{BuildID: "build-1", Name: "detect"})
+	b := podName(PhaseSpec{BuildID: "build-2", Name: "detect"})
+	c := podName(PhaseSpec{BuildID: "build-1", Name: "analyze"})
+
+	if a == b {
+		t.Errorf("expected pod names for different builds to differ, both were %s", a)
+	}
+	if a == c {
+		t.Errorf("expected pod names for different phases of the same build to differ, both were %s", a)
+	}
+}
+
+func TestSplitEnv(t *testing.T) {
+	name, value, ok := splitEnv("CNB_PLATFORM_OS=linux")
+	if !ok || name != "CNB_PLATFORM_OS" || value != "linux" {
+		t.Fatalf("expected (CNB_PLATFORM_OS, linux, true), got (%s, %s, %v)", name, value, ok)
+	}
+}
+
+func TestContainerExitCodeNotYetTerminated(t *testing.T) {
+	statuses := []corev1.ContainerStatus{{Name: "phase", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}}
+	if _, done := containerExitCode(statuses, "phase"); done {
+		t.Fatal("expected a running container to not report a terminal exit code yet")
+	}
+}
+
+func TestContainerExitCodeReportsNonZero(t *testing.T) {
+	statuses := []corev1.ContainerStatus{{Name: "phase", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}}}
+	code, done := containerExitCode(statuses, "phase")
+	if !done || code != 1 {
+		t.Fatalf("expected (1, true), got (%d, %v)", code, done)
+	}
+}
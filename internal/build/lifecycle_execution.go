@@ -0,0 +1,133 @@
+package build
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/docker/docker/client"
+
+	"github.com/buildpacks/pack/logging"
+)
+
+// mountPaths resolves the in-container paths phases use for the shared
+// layers/app volumes, which differ between Linux and Windows containers.
+type mountPaths struct {
+	os string
+}
+
+func (m mountPaths) layersDir() string {
+	if m.os == "windows" {
+		return `c:\layers`
+	}
+	return "/layers"
+}
+
+func (m mountPaths) appDir() string {
+	if m.os == "windows" {
+		return `c:\workspace`
+	}
+	return "/workspace"
+}
+
+// LifecycleExecution orchestrates running the CNB lifecycle's phases
+// (detect, analyze, restore, build, export) as a sequence of PhaseBackend
+// invocations for a single build or detect request.
+type LifecycleExecution struct {
+	logger      *logging.Logger
+	docker      client.CommonAPIClient
+	backend     PhaseBackend
+	opts        LifecycleOptions
+	os          string
+	platform    Platform
+	platformAPI *api.Version
+
+	layersVolume string
+	appVolume    string
+	mountPaths   mountPaths
+	buildID      string
+}
+
+// defaultPlatformAPI is used when callers don't resolve a specific platform
+// API version off the builder image (e.g. a quick `pack detect`).
+var defaultPlatformAPI = api.MustParse("0.9")
+
+// NewLifecycleExecution builds a LifecycleExecution that will run its
+// phases against backend (a DockerPhaseBackend, a KubernetesPhaseBackend,
+// ...). A fresh buildID is generated so that runtime resources backends
+// create (containers, pods, volumes) don't collide across concurrent runs.
+func NewLifecycleExecution(logger *logging.Logger, docker client.CommonAPIClient, backend PhaseBackend, os string, platformAPI *api.Version, opts LifecycleOptions) *LifecycleExecution {
+	if platformAPI == nil {
+		platformAPI = defaultPlatformAPI
+	}
+
+	buildID := randomID()
+	return &LifecycleExecution{
+		logger:       logger,
+		docker:       docker,
+		backend:      backend,
+		opts:         opts,
+		os:           os,
+		platform:     opts.Platform,
+		platformAPI:  platformAPI,
+		layersVolume: layersVolumeName(buildID),
+		appVolume:    appVolumeName(buildID),
+		mountPaths:   mountPaths{os: os},
+		buildID:      buildID,
+	}
+}
+
+// layersVolumeName and appVolumeName name the named volumes a single build
+// or detect invocation's phases share, derived from buildID so backends
+// that clean them up later (DockerPhaseBackend.CleanupBuild) can reconstruct
+// the same names without the LifecycleExecution that created them.
+func layersVolumeName(buildID string) string {
+	return fmt.Sprintf("pack-layers-%s", buildID)
+}
+
+func appVolumeName(buildID string) string {
+	return fmt.Sprintf("pack-app-%s", buildID)
+}
+
+// runPhase builds the named phase's container configuration, hands the
+// resulting PhaseSpec to the configured PhaseBackend, and streams its
+// output to the phase's info writer.
+func (l *LifecycleExecution) runPhase(ctx context.Context, name string, ops ...PhaseConfigProviderOperation) error {
+	provider := NewPhaseConfigProvider(name, l, ops...)
+	spec := provider.ToSpec()
+
+	output, err := l.backend.Run(ctx, spec)
+	if err != nil {
+		return fmt.Errorf("running %s phase: %w", name, err)
+	}
+	defer output.Close()
+	defer l.backend.Cleanup(ctx, spec)
+
+	if _, err := io.Copy(provider.InfoWriter(), output); err != nil {
+		return fmt.Errorf("streaming %s phase output: %w", name, err)
+	}
+	return nil
+}
+
+// appUploadOps returns the op that uploads opts.AppPath into the app
+// volume before a phase runs, or nil if no AppPath was given (e.g. when
+// the app source is already baked into the builder image).
+func (l *LifecycleExecution) appUploadOps() []PhaseConfigProviderOperation {
+	if l.opts.AppPath == "" {
+		return nil
+	}
+	return []PhaseConfigProviderOperation{WithContainerOperations(AppDirOp(l.opts.AppPath, l.mountPaths.appDir()))}
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read on the standard reader never returns an error in
+	// practice; a failure here would mean the OS entropy source is gone,
+	// which isn't something a build ID collision check could recover
+	// from either.
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,14 @@
+package build
+
+// LifecycleImage returns the builder image the lifecycle phases will run
+// as.
+func (l *LifecycleExecution) LifecycleImage() string {
+	return l.opts.Builder.Name()
+}
+
+// PreviousImage returns the image ref, if any, that analyze/restore/export
+// were asked to source previous labels/metadata from via
+// LifecycleOptions.PreviousImage.
+func (l *LifecycleExecution) PreviousImage() string {
+	return l.opts.PreviousImage
+}
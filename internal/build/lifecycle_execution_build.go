@@ -0,0 +1,72 @@
+package build
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/buildpacks/lifecycle/api"
+)
+
+// minPreviousImagePlatformAPI is the first platform API version whose
+// analyzer and exporter accept the `-previous-image` flag used to rebase
+// onto a previously built app image instead of analyzing from scratch.
+var minPreviousImagePlatformAPI = api.MustParse("0.7")
+
+// Build runs the full lifecycle (detect, analyze, restore, build, export)
+// in sequence, producing an app image.
+func (l *LifecycleExecution) Build(ctx context.Context) error {
+	defer l.backend.CleanupBuild(ctx, l.buildID)
+
+	previousImageOps, err := l.previousImageOps()
+	if err != nil {
+		return err
+	}
+
+	if err := l.runPhase(ctx, "detect", append(l.appUploadOps(), WithNetwork(l.opts.Network))...); err != nil {
+		return err
+	}
+	if err := l.runPhase(ctx, "analyze", append(l.layoutOps("analyze"), previousImageOps...)...); err != nil {
+		return err
+	}
+	if err := l.runPhase(ctx, "restore", append(l.layoutOps("restore"), previousImageOps...)...); err != nil {
+		return err
+	}
+	if err := l.runPhase(ctx, "build", WithNetwork(l.opts.Network)); err != nil {
+		return err
+	}
+	if err := l.runPhase(ctx, "export", append(append(l.layoutOps("export"), previousImageOps...), WithArgs(l.opts.Image))...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// previousImageOps returns the op needed to pass LifecycleOptions.PreviousImage
+// through to analyze/restore/export, rejecting it outright when the builder's
+// platform API predates `-previous-image` support rather than letting the
+// lifecycle fail opaquely mid-build.
+func (l *LifecycleExecution) previousImageOps() ([]PhaseConfigProviderOperation, error) {
+	if l.opts.PreviousImage == "" {
+		return nil, nil
+	}
+	if l.platformAPI.Compare(minPreviousImagePlatformAPI) < 0 {
+		return nil, fmt.Errorf("--previous-image requires platform API %s or greater, builder supports %s", minPreviousImagePlatformAPI, l.platformAPI)
+	}
+	return []PhaseConfigProviderOperation{WithPreviousImage(l.opts.PreviousImage)}, nil
+}
+
+// layoutOps returns the PhaseConfigProviderOperations a phase needs to read
+// or write images from an on-disk OCI layout directory instead of a daemon
+// or registry, when LifecycleOptions.Layout is set. The exporter additionally
+// gets a `-layout` flag, since it (unlike analyze/restore) has a
+// daemon-vs-layout mode selected explicitly rather than only by env var.
+func (l *LifecycleExecution) layoutOps(phase string) []PhaseConfigProviderOperation {
+	if l.opts.Layout == nil {
+		return nil
+	}
+
+	ops := []PhaseConfigProviderOperation{WithLayoutMode(l.opts.Layout.LayoutRepoDir)}
+	if phase == "export" {
+		ops = append(ops, WithFlags("-layout"))
+	}
+	return ops
+}
@@ -0,0 +1,85 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+)
+
+func TestLayoutOpsNilWhenNoLayout(t *testing.T) {
+	l := &LifecycleExecution{opts: LifecycleOptions{}}
+	if ops := l.layoutOps("analyze"); ops != nil {
+		t.Fatalf("expected no ops without a LayoutConfig, got %d", len(ops))
+	}
+}
+
+func TestLayoutOpsAppliesLayoutModeToAnalyze(t *testing.T) {
+	l := &LifecycleExecution{opts: LifecycleOptions{Layout: &LayoutConfig{LayoutRepoDir: "/tmp/layout"}}}
+	ops := l.layoutOps("analyze")
+	if len(ops) != 1 {
+		t.Fatalf("expected exactly the layout-mode op for analyze, got %d ops", len(ops))
+	}
+
+	provider := newTestProvider()
+	ops[0](provider)
+	if !containsString(provider.ctrConf.Env, "CNB_USE_LAYOUT=true") {
+		t.Errorf("expected analyze phase to set CNB_USE_LAYOUT=true, got env %v", provider.ctrConf.Env)
+	}
+}
+
+func TestLayoutOpsAddsLayoutFlagToExport(t *testing.T) {
+	l := &LifecycleExecution{opts: LifecycleOptions{Layout: &LayoutConfig{LayoutRepoDir: "/tmp/layout"}}}
+	ops := l.layoutOps("export")
+	if len(ops) != 2 {
+		t.Fatalf("expected layout-mode + -layout flag op for export, got %d ops", len(ops))
+	}
+
+	provider := newTestProvider()
+	for _, op := range ops {
+		op(provider)
+	}
+	if len(provider.ctrConf.Cmd) == 0 || provider.ctrConf.Cmd[0] != "-layout" {
+		t.Fatalf("expected -layout to be prepended to export's Cmd, got %v", provider.ctrConf.Cmd)
+	}
+}
+
+func TestPreviousImageOpsNilWhenUnset(t *testing.T) {
+	l := &LifecycleExecution{opts: LifecycleOptions{}, platformAPI: api.MustParse("0.9")}
+	ops, err := l.previousImageOps()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ops != nil {
+		t.Fatalf("expected no ops without a PreviousImage, got %d", len(ops))
+	}
+}
+
+func TestPreviousImageOpsRejectsOldPlatformAPI(t *testing.T) {
+	l := &LifecycleExecution{
+		opts:        LifecycleOptions{PreviousImage: "index.docker.io/some/previous-image"},
+		platformAPI: api.MustParse("0.6"),
+	}
+	if _, err := l.previousImageOps(); err == nil {
+		t.Fatal("expected an error for a platform API that predates -previous-image support")
+	}
+}
+
+func TestPreviousImageOpsAppliesFlag(t *testing.T) {
+	l := &LifecycleExecution{
+		opts:        LifecycleOptions{PreviousImage: "index.docker.io/some/previous-image"},
+		platformAPI: api.MustParse("0.7"),
+	}
+	ops, err := l.previousImageOps()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("expected exactly one op, got %d", len(ops))
+	}
+
+	provider := newTestProvider()
+	ops[0](provider)
+	if len(provider.ctrConf.Cmd) < 2 || provider.ctrConf.Cmd[0] != "-previous-image" {
+		t.Fatalf("expected -previous-image to be set on Cmd, got %v", provider.ctrConf.Cmd)
+	}
+}
@@ -0,0 +1,109 @@
+package build
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Group mirrors group.toml as written by the detect phase: the ordered list
+// of buildpacks selected to build this app.
+type Group struct {
+	Group []GroupBuildpack `toml:"group"`
+}
+
+// GroupBuildpack identifies a single buildpack entry in a Group or Plan.
+type GroupBuildpack struct {
+	ID      string `toml:"id"`
+	Version string `toml:"version"`
+}
+
+// Plan mirrors plan.toml, produced by the detect phase on platform APIs
+// that support one (v0.10+).
+type Plan struct {
+	Entries []PlanEntry `toml:"entries"`
+}
+
+// PlanEntry is a single requirement resolved during detection and the
+// buildpacks that provide it.
+type PlanEntry struct {
+	Providers []GroupBuildpack `toml:"providers"`
+	Requires  []PlanRequire    `toml:"requires"`
+}
+
+// PlanRequire is a single named requirement within a PlanEntry.
+type PlanRequire struct {
+	Name string `toml:"name"`
+}
+
+// Detect runs only the analyze and detect phases (skipping
+// restore/build/export) and returns the resolved group, plus the detect
+// plan on platform APIs that produce one.
+func (l *LifecycleExecution) Detect(ctx context.Context) (Group, Plan, error) {
+	defer l.backend.CleanupBuild(ctx, l.buildID)
+
+	if err := l.runPhase(ctx, "analyze", WithFlags("-skip-layers")); err != nil {
+		return Group{}, Plan{}, err
+	}
+	if err := l.runPhase(ctx, "detect", l.appUploadOps()...); err != nil {
+		return Group{}, Plan{}, err
+	}
+
+	group, err := l.readGroup(ctx)
+	if err != nil {
+		return Group{}, Plan{}, err
+	}
+
+	plan, err := l.readPlan(ctx)
+	if err != nil {
+		return Group{}, Plan{}, err
+	}
+
+	return group, plan, nil
+}
+
+func (l *LifecycleExecution) readGroup(ctx context.Context) (Group, error) {
+	raw, err := l.readLayersFile(ctx, "group.toml")
+	if err != nil {
+		return Group{}, fmt.Errorf("reading group.toml: %w", err)
+	}
+	return parseGroup(raw)
+}
+
+func (l *LifecycleExecution) readPlan(ctx context.Context) (Plan, error) {
+	raw, err := l.readLayersFile(ctx, "plan.toml")
+	if err != nil {
+		// Not every platform API produces a plan.toml; treat a missing
+		// file as an empty plan rather than a hard failure.
+		return Plan{}, nil
+	}
+	return parsePlan(raw)
+}
+
+func parseGroup(raw []byte) (Group, error) {
+	var group Group
+	if _, err := toml.Decode(string(raw), &group); err != nil {
+		return Group{}, fmt.Errorf("parsing group.toml: %w", err)
+	}
+	return group, nil
+}
+
+func parsePlan(raw []byte) (Plan, error) {
+	var plan Plan
+	if _, err := toml.Decode(string(raw), &plan); err != nil {
+		return Plan{}, fmt.Errorf("parsing plan.toml: %w", err)
+	}
+	return plan, nil
+}
+
+// readLayersFile pulls a single file out of the layers volume, going
+// through the configured PhaseBackend rather than reaching into a
+// particular runtime's API directly.
+func (l *LifecycleExecution) readLayersFile(ctx context.Context, name string) ([]byte, error) {
+	spec := PhaseSpec{
+		Image:  l.opts.Builder.Name(),
+		Mounts: []PhaseMount{{Source: l.layersVolume, Target: l.mountPaths.layersDir()}},
+	}
+	return l.backend.ReadFile(ctx, spec, l.mountPaths.layersDir()+"/"+name)
+}
@@ -0,0 +1,41 @@
+package build
+
+import "testing"
+
+func TestParseGroup(t *testing.T) {
+	group, err := parseGroup([]byte(`
+[[group]]
+id = "some/buildpack"
+version = "1.2.3"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(group.Group) != 1 || group.Group[0].ID != "some/buildpack" || group.Group[0].Version != "1.2.3" {
+		t.Fatalf("expected a single resolved buildpack, got %+v", group.Group)
+	}
+}
+
+func TestParsePlanEmptyInput(t *testing.T) {
+	plan, err := parsePlan([]byte(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Entries) != 0 {
+		t.Fatalf("expected no entries for an empty plan.toml, got %+v", plan.Entries)
+	}
+}
+
+func TestParsePlanWithEntries(t *testing.T) {
+	plan, err := parsePlan([]byte(`
+[[entries]]
+  [[entries.requires]]
+  name = "node"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Entries) != 1 || len(plan.Entries[0].Requires) != 1 || plan.Entries[0].Requires[0].Name != "node" {
+		t.Fatalf("expected one entry requiring node, got %+v", plan.Entries)
+	}
+}
@@ -0,0 +1,42 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppUploadOpsNilWhenUnset(t *testing.T) {
+	l := &LifecycleExecution{opts: LifecycleOptions{}}
+	if ops := l.appUploadOps(); ops != nil {
+		t.Fatalf("expected no ops without an AppPath, got %d", len(ops))
+	}
+}
+
+func TestAppUploadOpsUploadsAppPath(t *testing.T) {
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatalf("writing fixture file: %v", err)
+	}
+
+	l := &LifecycleExecution{opts: LifecycleOptions{AppPath: appDir}, mountPaths: mountPaths{os: "linux"}}
+	ops := l.appUploadOps()
+	if len(ops) != 1 {
+		t.Fatalf("expected exactly one op, got %d", len(ops))
+	}
+
+	provider := newTestProvider()
+	ops[0](provider)
+	if len(provider.containerOps) != 1 {
+		t.Fatalf("expected the op to register a ContainerOperation, got %d", len(provider.containerOps))
+	}
+}
+
+func TestLayersVolumeAndAppVolumeNamesAreDeterministic(t *testing.T) {
+	if layersVolumeName("abc") != "pack-layers-abc" {
+		t.Errorf("expected pack-layers-abc, got %s", layersVolumeName("abc"))
+	}
+	if appVolumeName("abc") != "pack-app-abc" {
+		t.Errorf("expected pack-app-abc, got %s", appVolumeName("abc"))
+	}
+}
@@ -0,0 +1,54 @@
+package build
+
+// Builder is the minimal surface LifecycleOptions needs from a resolved
+// builder image.
+type Builder interface {
+	Name() string
+}
+
+// LayoutConfig points the lifecycle at an on-disk OCI image layout
+// directory to use instead of a daemon or registry, for both the base run
+// image and the produced app image.
+type LayoutConfig struct {
+	InputImage         string
+	PreviousInputImage string
+	LayoutRepoDir      string
+}
+
+// LifecycleOptions configures a single LifecycleExecution run.
+type LifecycleOptions struct {
+	Builder Builder
+
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+
+	Network string
+
+	// Platform is the target OS/architecture/variant the lifecycle's
+	// phase containers should run as. A zero value means "whatever the
+	// backend/daemon defaults to".
+	Platform Platform
+
+	// PreviousImage is a ref analyze/restore/export should source
+	// previous labels/metadata from when rebasing onto a new run image.
+	PreviousImage string
+
+	// Layout, when set, switches the lifecycle to reading/writing images
+	// from an on-disk OCI layout directory instead of a daemon/registry.
+	Layout *LayoutConfig
+
+	// DetectOnly marks a run made by `pack detect`. LifecycleExecution does
+	// not read it: Detect always runs only analyze+detect, and Build always
+	// runs every phase.
+	DetectOnly bool
+
+	// AppPath is the host directory containing the app source to upload
+	// into the lifecycle's app volume before running. Empty means the
+	// builder image already has app source baked in.
+	AppPath string
+
+	// Image is the ref the export phase should tag the produced app image
+	// as.
+	Image string
+}
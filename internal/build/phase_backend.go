@@ -0,0 +1,104 @@
+package build
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// PhaseSpec is a runtime-neutral description of a single lifecycle phase
+// (detect, analyze, restore, build, export, ...). A PhaseBackend translates
+// a PhaseSpec into whatever primitives its container runtime requires.
+type PhaseSpec struct {
+	Name string
+
+	// BuildID uniquely identifies the build or detect invocation this phase
+	// belongs to, so backends that name runtime resources after it (e.g.
+	// Kubernetes Pods) don't collide across concurrent builds or across
+	// this build's own phases.
+	BuildID string
+
+	Image    string
+	Cmd      []string
+	Env      []string
+	User     string
+	Platform Platform
+
+	// Network is the runtime-specific network mode the phase should run
+	// under (e.g. docker's "none", "host", or a named network).
+	Network string
+
+	// Isolation is the runtime-specific isolation technology to use, if
+	// any (e.g. "process" or "hyperv" on Windows).
+	Isolation string
+
+	Mounts []PhaseMount
+
+	ContainerOps []ContainerOperation
+
+	InfoWriter  io.Writer
+	ErrorWriter io.Writer
+}
+
+// PhaseMount is a runtime-neutral bind between a host path or named volume
+// and an in-container target directory. KubernetesPhaseBackend does not use
+// Source; it mounts a fresh emptyDir volume at Target.
+type PhaseMount struct {
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// PhaseBackend runs a single lifecycle phase described by a PhaseSpec and
+// returns a combined stdout/stderr stream for the caller to copy to the
+// phase's InfoWriter/ErrorWriter. Implementations are expected to block
+// until the phase's process has exited, and to return an error if it exited
+// non-zero.
+type PhaseBackend interface {
+	Run(ctx context.Context, spec PhaseSpec) (io.ReadCloser, error)
+
+	// Cleanup releases any runtime resources (a container, a pod, ...)
+	// created on behalf of spec by a previous call to Run.
+	Cleanup(ctx context.Context, spec PhaseSpec) error
+
+	// CleanupBuild releases runtime resources shared across every phase of
+	// a single build or detect invocation (e.g. Docker's per-build
+	// layers/app named volumes), identified by buildID. Called once after
+	// the full phase sequence finishes, whether it succeeded or failed.
+	CleanupBuild(ctx context.Context, buildID string) error
+
+	// ReadFile returns the contents of path as seen by a container with
+	// spec's image and mounts (spec need not have been passed to Run),
+	// without requiring the caller to reach into runtime-specific
+	// primitives of its own.
+	ReadFile(ctx context.Context, spec PhaseSpec, path string) ([]byte, error)
+}
+
+// ToSpec converts a PhaseConfigProvider's accumulated container and host
+// configuration into a backend-neutral PhaseSpec.
+func (p *PhaseConfigProvider) ToSpec() PhaseSpec {
+	mounts := make([]PhaseMount, 0, len(p.hostConf.Binds))
+	for _, bind := range p.hostConf.Binds {
+		parts := strings.SplitN(bind, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		mounts = append(mounts, PhaseMount{Source: parts[0], Target: parts[1]})
+	}
+
+	return PhaseSpec{
+		Name:         p.name,
+		BuildID:      p.buildID,
+		Image:        p.ctrConf.Image,
+		Cmd:          p.ctrConf.Cmd,
+		Env:          p.ctrConf.Env,
+		User:         p.ctrConf.User,
+		Platform:     p.platform,
+		Network:      string(p.hostConf.NetworkMode),
+		Isolation:    string(p.hostConf.Isolation),
+		Mounts:       mounts,
+		ContainerOps: p.containerOps,
+		InfoWriter:   p.infoWriter,
+		ErrorWriter:  p.errorWriter,
+	}
+}
@@ -0,0 +1,79 @@
+package build
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestToSpecConvertsBindsToMounts(t *testing.T) {
+	provider := newTestProvider()
+	provider.buildID = "build-1"
+	provider.ctrConf.Image = "index.docker.io/some/builder"
+	provider.hostConf.Binds = []string{"pack-layers-build-1:/layers", "pack-app-build-1:/workspace"}
+	provider.hostConf.Isolation = container.IsolationProcess
+	provider.platform = Platform{OS: "linux", Architecture: "amd64"}
+
+	spec := provider.ToSpec()
+
+	if spec.BuildID != "build-1" {
+		t.Errorf("expected BuildID to carry over, got %q", spec.BuildID)
+	}
+	if spec.Image != "index.docker.io/some/builder" {
+		t.Errorf("expected Image to carry over, got %q", spec.Image)
+	}
+	if len(spec.Mounts) != 2 || spec.Mounts[0].Source != "pack-layers-build-1" || spec.Mounts[0].Target != "/layers" {
+		t.Fatalf("expected binds to convert to mounts, got %+v", spec.Mounts)
+	}
+	if spec.Platform.Architecture != "amd64" {
+		t.Errorf("expected platform to carry over, got %+v", spec.Platform)
+	}
+}
+
+// fakeBackend is a minimal PhaseBackend that records the name and spec of
+// every phase it is asked to Run.
+type fakeBackend struct {
+	ran   []string
+	specs []PhaseSpec
+}
+
+func (f *fakeBackend) Run(ctx context.Context, spec PhaseSpec) (io.ReadCloser, error) {
+	f.ran = append(f.ran, spec.Name)
+	f.specs = append(f.specs, spec)
+	return io.NopCloser(strReader("")), nil
+}
+
+func (f *fakeBackend) Cleanup(ctx context.Context, spec PhaseSpec) error {
+	return nil
+}
+
+func (f *fakeBackend) CleanupBuild(ctx context.Context, buildID string) error {
+	return nil
+}
+
+func (f *fakeBackend) ReadFile(ctx context.Context, spec PhaseSpec, path string) ([]byte, error) {
+	return nil, nil
+}
+
+type strReader string
+
+func (s strReader) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+var _ PhaseBackend = (*fakeBackend)(nil)
+
+func TestFakeBackendRecordsRunCalls(t *testing.T) {
+	backend := &fakeBackend{}
+	spec := PhaseSpec{Name: "detect"}
+
+	if _, err := backend.Run(context.Background(), spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(backend.ran) != 1 || backend.ran[0] != "detect" {
+		t.Fatalf("expected backend to record the detect phase, got %v", backend.ran)
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"io"
 	"strings"
 
-	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/logging"
@@ -16,6 +16,12 @@ const (
 	linuxContainerAdmin   = "root"
 	windowsContainerAdmin = `NT AUTHORITY\SYSTEM`
 	platformAPIEnvVar     = "CNB_PLATFORM_API"
+	layoutDirContainer    = "/layout"
+	useLayoutEnvVar       = "CNB_USE_LAYOUT"
+	layoutDirEnvVar       = "CNB_LAYOUT_DIR"
+	platformOSEnvVar      = "CNB_PLATFORM_OS"
+	platformArchEnvVar    = "CNB_PLATFORM_ARCH"
+	platformVariantEnvVar = "CNB_PLATFORM_VARIANT"
 )
 
 type PhaseConfigProviderOperation func(*PhaseConfigProvider)
@@ -25,7 +31,9 @@ type PhaseConfigProvider struct {
 	ctrExecs     []*types.ExecConfig
 	hostConf     *container.HostConfig
 	name         string
+	buildID      string
 	os           string
+	platform     Platform
 	containerOps []ContainerOperation
 	infoWriter   io.Writer
 	errorWriter  io.Writer
@@ -36,7 +44,9 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		ctrConf:     new(container.Config),
 		hostConf:    new(container.HostConfig),
 		name:        name,
+		buildID:     lifecycleExec.buildID,
 		os:          lifecycleExec.os,
+		platform:    lifecycleExec.platform,
 		infoWriter:  logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel),
 		errorWriter: logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel),
 	}
@@ -45,7 +55,11 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	provider.ctrConf.Labels = map[string]string{"author": "pack"}
 
 	if lifecycleExec.os == "windows" {
-		provider.hostConf.Isolation = container.IsolationProcess
+		provider.hostConf.Isolation = isolationFor(lifecycleExec.os, provider.platform)
+	}
+
+	if !provider.platform.isEmpty() {
+		ops = append(ops, WithPlatform(provider.platform))
 	}
 
 	ops = append(ops,
@@ -77,6 +91,20 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	return provider
 }
 
+// isolationFor picks the container isolation technology a phase's host
+// container config should use. A Linux target platform on a Windows host
+// means the phase should run as an LCOW (Linux-on-Windows) container, which
+// requires Hyper-V isolation instead of plain process isolation.
+func isolationFor(hostOS string, platform Platform) container.Isolation {
+	if hostOS != "windows" {
+		return container.IsolationDefault
+	}
+	if !platform.isEmpty() && platform.OS == "linux" {
+		return container.IsolationHyperV
+	}
+	return container.IsolationProcess
+}
+
 func (p *PhaseConfigProvider) ContainerConfig() *container.Config {
 	return p.ctrConf
 }
@@ -93,6 +121,10 @@ func (p *PhaseConfigProvider) Name() string {
 	return p.name
 }
 
+func (p *PhaseConfigProvider) Platform() Platform {
+	return p.platform
+}
+
 func (p *PhaseConfigProvider) ErrorWriter() io.Writer {
 	return p.errorWriter
 }
@@ -147,6 +179,47 @@ func WithImage(image string) PhaseConfigProviderOperation {
 	}
 }
 
+// WithPlatform records the target platform a phase should run as and
+// propagates it to the lifecycle via CNB_PLATFORM_* env vars. The actual
+// cross-arch/LCOW execution is requested of the container runtime
+// separately, since that's set at container-create time rather than via
+// PhaseConfigProviderOperation.
+func WithPlatform(platform Platform) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.platform = platform
+		provider.ctrConf.Env = append(provider.ctrConf.Env,
+			fmt.Sprintf("%s=%s", platformOSEnvVar, platform.OS),
+			fmt.Sprintf("%s=%s", platformArchEnvVar, platform.Architecture),
+		)
+		if platform.Variant != "" {
+			provider.ctrConf.Env = append(provider.ctrConf.Env, fmt.Sprintf("%s=%s", platformVariantEnvVar, platform.Variant))
+		}
+	}
+}
+
+// WithPreviousImage adds the `-previous-image <ref>` flag to a phase's
+// invocation, so analyze/restore/export can source labels/metadata from an
+// older tag of the app image being rebuilt, rather than requiring callers to
+// re-tag onto the new run image first.
+func WithPreviousImage(ref string) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		WithFlags("-previous-image", ref)(provider)
+	}
+}
+
+// WithLayoutMode binds an on-disk OCI image layout directory into the
+// container at /layout and sets CNB_USE_LAYOUT/CNB_LAYOUT_DIR so the
+// lifecycle reads/writes images there instead of a daemon or registry.
+func WithLayoutMode(layoutDir string) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.hostConf.Binds = append(provider.hostConf.Binds, fmt.Sprintf("%s:%s", layoutDir, layoutDirContainer))
+		provider.ctrConf.Env = append(provider.ctrConf.Env,
+			fmt.Sprintf("%s=true", useLayoutEnvVar),
+			fmt.Sprintf("%s=%s", layoutDirEnvVar, layoutDirContainer),
+		)
+	}
+}
+
 // WithLogPrefix sets a prefix for logs produced by this phase
 func WithLogPrefix(prefix string) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
@@ -196,10 +269,10 @@ func WithRoot() PhaseConfigProviderOperation {
 			// exec process as default user than can be impersonated by SYSTEM user
 			// run cmd in the background to prompt for input forever
 			provider.ctrExecs = []*types.ExecConfig{{
-				Cmd:          []string{"cmd.exe", "/c", "set /p wait="},
-				Detach:       true,
-				AttachStdin:  true,
-				User:         "",
+				Cmd:         []string{"cmd.exe", "/c", "set /p wait="},
+				Detach:      true,
+				AttachStdin: true,
+				User:        "",
 			}}
 		} else {
 			provider.ctrConf.User = linuxContainerAdmin
@@ -0,0 +1,88 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func newTestProvider() *PhaseConfigProvider {
+	return &PhaseConfigProvider{
+		ctrConf:  new(container.Config),
+		hostConf: new(container.HostConfig),
+		name:     "detect",
+	}
+}
+
+func TestWithPlatform(t *testing.T) {
+	provider := newTestProvider()
+	WithPlatform(Platform{OS: "linux", Architecture: "arm64", Variant: "v8"})(provider)
+
+	if provider.platform.Architecture != "arm64" {
+		t.Fatalf("expected provider.platform.Architecture to be arm64, got %s", provider.platform.Architecture)
+	}
+
+	wantEnvs := []string{"CNB_PLATFORM_OS=linux", "CNB_PLATFORM_ARCH=arm64", "CNB_PLATFORM_VARIANT=v8"}
+	for _, want := range wantEnvs {
+		if !containsString(provider.ctrConf.Env, want) {
+			t.Errorf("expected container env to contain %q, got %v", want, provider.ctrConf.Env)
+		}
+	}
+}
+
+func TestWithPreviousImage(t *testing.T) {
+	provider := newTestProvider()
+	WithPreviousImage("index.docker.io/some/previous-image")(provider)
+
+	want := []string{"-previous-image", "index.docker.io/some/previous-image"}
+	if len(provider.ctrConf.Cmd) < 2 || provider.ctrConf.Cmd[0] != want[0] || provider.ctrConf.Cmd[1] != want[1] {
+		t.Fatalf("expected Cmd to start with %v, got %v", want, provider.ctrConf.Cmd)
+	}
+}
+
+func TestWithLayoutMode(t *testing.T) {
+	provider := newTestProvider()
+	WithLayoutMode("/tmp/some-layout-dir")(provider)
+
+	wantBind := "/tmp/some-layout-dir:/layout"
+	if !containsString(provider.hostConf.Binds, wantBind) {
+		t.Errorf("expected host binds to contain %q, got %v", wantBind, provider.hostConf.Binds)
+	}
+
+	wantEnvs := []string{"CNB_USE_LAYOUT=true", "CNB_LAYOUT_DIR=/layout"}
+	for _, want := range wantEnvs {
+		if !containsString(provider.ctrConf.Env, want) {
+			t.Errorf("expected container env to contain %q, got %v", want, provider.ctrConf.Env)
+		}
+	}
+}
+
+func TestIsolationForLCOW(t *testing.T) {
+	got := isolationFor("windows", Platform{OS: "linux", Architecture: "amd64"})
+	if got != container.IsolationHyperV {
+		t.Fatalf("expected LCOW phase to use Hyper-V isolation, got %s", got)
+	}
+}
+
+func TestIsolationForWindowsProcess(t *testing.T) {
+	got := isolationFor("windows", Platform{})
+	if got != container.IsolationProcess {
+		t.Fatalf("expected non-LCOW windows phase to use process isolation, got %s", got)
+	}
+}
+
+func TestIsolationForLinuxHost(t *testing.T) {
+	got := isolationFor("linux", Platform{})
+	if got != container.IsolationDefault {
+		t.Fatalf("expected linux host to use default isolation, got %s", got)
+	}
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform describes the target runtime platform that a phase's container
+// should execute as, mirroring the fields of an OCI image-index manifest
+// list entry. A zero-value Platform means "whatever the host/daemon
+// defaults to".
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+	OSVersion    string
+}
+
+func (p Platform) isEmpty() bool {
+	return p == Platform{}
+}
+
+// ParsePlatform parses a `--platform` flag value of the form
+// "os/arch[/variant]" (matching `docker buildx`'s syntax), returning a
+// zero-value Platform for an empty string.
+func ParsePlatform(s string) (Platform, error) {
+	if s == "" {
+		return Platform{}, nil
+	}
+
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Platform{}, fmt.Errorf("invalid platform %q: expected os/arch[/variant]", s)
+	}
+
+	platform := Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		platform.Variant = parts[2]
+	}
+	return platform, nil
+}
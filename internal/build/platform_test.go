@@ -0,0 +1,39 @@
+package build
+
+import "testing"
+
+func TestParsePlatformEmptyString(t *testing.T) {
+	p, err := ParsePlatform("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.isEmpty() {
+		t.Fatalf("expected an empty string to parse to the zero value, got %+v", p)
+	}
+}
+
+func TestParsePlatformOSArch(t *testing.T) {
+	p, err := ParsePlatform("linux/arm64")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.OS != "linux" || p.Architecture != "arm64" || p.Variant != "" {
+		t.Fatalf("expected {linux arm64 \"\"}, got %+v", p)
+	}
+}
+
+func TestParsePlatformOSArchVariant(t *testing.T) {
+	p, err := ParsePlatform("linux/arm/v7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.OS != "linux" || p.Architecture != "arm" || p.Variant != "v7" {
+		t.Fatalf("expected {linux arm v7}, got %+v", p)
+	}
+}
+
+func TestParsePlatformRejectsMalformed(t *testing.T) {
+	if _, err := ParsePlatform("linux"); err == nil {
+		t.Fatal("expected an error for a platform string missing an architecture")
+	}
+}
@@ -0,0 +1,54 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// PodmanPhaseBackend runs lifecycle phases against a rootless Podman daemon
+// over its libpod REST socket (see
+// https://docs.podman.io/en/latest/_static/api.html). It is currently a
+// stub: the socket plumbing is in place, but phase execution is not yet
+// implemented.
+type PodmanPhaseBackend struct {
+	httpClient *http.Client
+	socketPath string
+}
+
+// NewPodmanPhaseBackend returns a PodmanPhaseBackend that talks to the
+// libpod REST API over the unix socket at socketPath (typically
+// /run/podman/podman.sock for a rootless user).
+func NewPodmanPhaseBackend(socketPath string) *PodmanPhaseBackend {
+	return &PodmanPhaseBackend{
+		socketPath: socketPath,
+		httpClient: &http.Client{Transport: &http.Transport{
+			DialContext: unixDialer(socketPath),
+		}},
+	}
+}
+
+func (b *PodmanPhaseBackend) Run(ctx context.Context, spec PhaseSpec) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("podman backend does not yet support running phase %s", spec.Name)
+}
+
+func (b *PodmanPhaseBackend) Cleanup(ctx context.Context, spec PhaseSpec) error {
+	return nil
+}
+
+func (b *PodmanPhaseBackend) CleanupBuild(ctx context.Context, buildID string) error {
+	return nil
+}
+
+func (b *PodmanPhaseBackend) ReadFile(ctx context.Context, spec PhaseSpec, path string) ([]byte, error) {
+	return nil, fmt.Errorf("podman backend does not yet support reading files back out of phase %s", spec.Name)
+}
+
+func unixDialer(socketPath string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+}
@@ -49,7 +49,6 @@ func FilePathToURI(p string) (string, error) {
 // - windows drive: file:///C:/Documents%20and%20Settings/file.tgz
 //
 // - windows share: file://laptop/My%20Documents/file.tgz
-//
 func URIToFilePath(uri string) (string, error) {
 	var (
 		osPath string
@@ -71,6 +70,17 @@ func URIToFilePath(uri string) (string, error) {
 	return osPath, nil
 }
 
+// LayoutDirToRef resolves an on-disk OCI image layout directory to its
+// canonical `oci:<abs-path>` reference, the form consumed by the layout
+// image transport.
+func LayoutDirToRef(layoutDir string) (string, error) {
+	abs, err := filepath.Abs(layoutDir)
+	if err != nil {
+		return "", err
+	}
+	return "oci:" + abs, nil
+}
+
 func ToAbsolute(uri, relativeTo string) (string, error) {
 	parsed, err := url.Parse(uri)
 	if err != nil {
@@ -105,8 +115,8 @@ func FilterReservedNames(p string) string {
 	return p
 }
 
-//WindowsDir is equivalent to path.Dir or filepath.Dir but always for Windows paths
-//reproduced because Windows implementation is not exported
+// WindowsDir is equivalent to path.Dir or filepath.Dir but always for Windows paths
+// reproduced because Windows implementation is not exported
 func WindowsDir(p string) string {
 	pathElements := strings.Split(p, `\`)
 	if len(pathElements) < 1 {
@@ -118,8 +128,8 @@ func WindowsDir(p string) string {
 	return dirName
 }
 
-//WindowsBasename is equivalent to path.Basename or filepath.Basename but always for Windows paths
-//reproduced because Windows implementation is not exported
+// WindowsBasename is equivalent to path.Basename or filepath.Basename but always for Windows paths
+// reproduced because Windows implementation is not exported
 func WindowsBasename(p string) string {
 	pathElements := strings.Split(p, `\`)
 	if len(pathElements) < 1 {
@@ -129,13 +139,13 @@ func WindowsBasename(p string) string {
 	return pathElements[len(pathElements)-1]
 }
 
-//WindowsToSlash is equivalent to path.Basename or filepath.Basename but always for Windows paths
-//reproduced because Windows implementation is not exported
+// WindowsToSlash converts a Windows path's backslashes to forward slashes and strips its volume (e.g. `c:`)
+// reproduced because Windows implementation is not exported
 func WindowsToSlash(p string) string {
 	return strings.ReplaceAll(p, `\`, "/")[2:] // strip volume, convert slashes
 }
 
-//WindowsPathSID returns the appropriate SID for a given UID and GID
+// WindowsPathSID returns the appropriate SID for a given UID and GID
 func WindowsPathSID(uid, gid int) string {
 	if uid == 0 && gid == 0 {
 		return "S-1-5-32-544" // BUILTIN\Administrators
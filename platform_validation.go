@@ -0,0 +1,49 @@
+package pack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/registry"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// distributionInspector is the slice of client.CommonAPIClient
+// validatePlatform needs, broken out so it can be exercised with a fake in
+// tests instead of a full Docker client.
+type distributionInspector interface {
+	DistributionInspect(ctx context.Context, image, encodedAuth string) (registry.DistributionInspect, error)
+}
+
+// validatePlatform rejects an explicit --platform selection the builder
+// image's registry manifest list doesn't actually publish. A zero-value
+// Platform means no explicit selection was made and is always accepted.
+func (c *Client) validatePlatform(ctx context.Context, builderName string, requested build.Platform) error {
+	return validatePlatform(ctx, c.docker, builderName, requested)
+}
+
+func validatePlatform(ctx context.Context, docker distributionInspector, builderName string, requested build.Platform) error {
+	if requested == (build.Platform{}) {
+		return nil
+	}
+
+	info, err := docker.DistributionInspect(ctx, builderName, "")
+	if err != nil {
+		return fmt.Errorf("inspecting manifest for builder %s: %w", style.Symbol(builderName), err)
+	}
+
+	candidates := info.Platforms
+	if info.Descriptor.Platform != nil {
+		candidates = append(candidates, *info.Descriptor.Platform)
+	}
+
+	for _, p := range candidates {
+		if p.OS == requested.OS && p.Architecture == requested.Architecture {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("builder %s does not publish a %s/%s image", style.Symbol(builderName), requested.OS, requested.Architecture)
+}
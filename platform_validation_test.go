@@ -0,0 +1,49 @@
+package pack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/buildpacks/pack/internal/build"
+)
+
+type fakeDistributionInspector struct {
+	platforms []v1.Platform
+	err       error
+}
+
+func (f fakeDistributionInspector) DistributionInspect(ctx context.Context, image, encodedAuth string) (registry.DistributionInspect, error) {
+	if f.err != nil {
+		return registry.DistributionInspect{}, f.err
+	}
+	return registry.DistributionInspect{Platforms: f.platforms}, nil
+}
+
+func TestValidatePlatformSkipsZeroValue(t *testing.T) {
+	err := validatePlatform(context.Background(), fakeDistributionInspector{}, "some/builder", build.Platform{})
+	if err != nil {
+		t.Fatalf("expected no validation for an unset platform, got %v", err)
+	}
+}
+
+func TestValidatePlatformAcceptsMatchAnywhereInManifestList(t *testing.T) {
+	docker := fakeDistributionInspector{platforms: []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	}}
+	err := validatePlatform(context.Background(), docker, "some/builder", build.Platform{OS: "linux", Architecture: "arm64"})
+	if err != nil {
+		t.Fatalf("expected matching platform to validate, got %v", err)
+	}
+}
+
+func TestValidatePlatformRejectsMismatch(t *testing.T) {
+	docker := fakeDistributionInspector{platforms: []v1.Platform{{OS: "linux", Architecture: "amd64"}}}
+	err := validatePlatform(context.Background(), docker, "some/builder", build.Platform{OS: "linux", Architecture: "arm64"})
+	if err == nil {
+		t.Fatal("expected a mismatched architecture to be rejected")
+	}
+}